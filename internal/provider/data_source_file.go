@@ -7,17 +7,34 @@
 package provider
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
 	"os"
+	"path/filepath"
 	"runtime"
 	"strings"
 
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+// hashChunkSize is the buffer size used to stream existing file contents
+// through sha256 when checking for drift, so skip_unchanged doesn't have
+// to read the whole file into memory at once.
+const hashChunkSize = 64 * 1024
+
 var (
 	_ datasource.DataSource = &fileDataSource{}
 )
@@ -26,13 +43,22 @@ type fileDataSource struct {
 }
 
 type fileDataSourceModel struct {
-	Files           []*fileModel `tfsdk:"files"`
-	AddNewlineAtEnd types.Bool   `tfsdk:"add_newline_at_end"`
+	Files           []*fileModel   `tfsdk:"files"`
+	AddNewlineAtEnd types.Bool     `tfsdk:"add_newline_at_end"`
+	SkipUnchanged   types.Bool     `tfsdk:"skip_unchanged"`
+	PruneRoots      []types.String `tfsdk:"prune_roots"`
+	PruneGlobs      []types.String `tfsdk:"prune_globs"`
+	DryRun          types.Bool     `tfsdk:"dry_run"`
 }
 
 type fileModel struct {
-	Filename     types.String `tfsdk:"filename"`
-	FileContents types.String `tfsdk:"file_contents"`
+	Filename            types.String `tfsdk:"filename"`
+	FileContents        types.String `tfsdk:"file_contents"`
+	ContentBase64       types.String `tfsdk:"content_base64"`
+	SourcePath          types.String `tfsdk:"source_path"`
+	FilePermission      types.String `tfsdk:"file_permission"`
+	DirectoryPermission types.String `tfsdk:"directory_permission"`
+	ContentSha256       types.String `tfsdk:"content_sha256"`
 }
 
 func NewFileDataSource() datasource.DataSource {
@@ -55,9 +81,37 @@ func (r *fileDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, r
 							Required:    true,
 						},
 						"file_contents": schema.StringAttribute{
-							Description: "Text to put in the file",
-							Required:    true,
+							Description: "Text to put in the file. Exactly one of file_contents, content_base64, or source_path must be set.",
+							Optional:    true,
 							Sensitive:   true,
+							Validators: []validator.String{
+								stringvalidator.ExactlyOneOf(
+									path.MatchRelative(),
+									path.MatchRelative().AtParent().AtName("content_base64"),
+									path.MatchRelative().AtParent().AtName("source_path"),
+								),
+							},
+						},
+						"content_base64": schema.StringAttribute{
+							Description: "Base64-encoded contents to put in the file, for binary data. Conflicts with file_contents and source_path.",
+							Optional:    true,
+							Sensitive:   true,
+						},
+						"source_path": schema.StringAttribute{
+							Description: "Path to an existing file to copy bytes from. Streamed directly to the target so large files don't need to sit in Terraform state. Conflicts with file_contents and content_base64.",
+							Optional:    true,
+						},
+						"file_permission": schema.StringAttribute{
+							Description: "Permissions to set on the created file, expressed as a 4-digit octal string. Defaults to \"0644\".",
+							Optional:    true,
+						},
+						"directory_permission": schema.StringAttribute{
+							Description: "Permissions to set on any parent directories created for the file, expressed as a 4-digit octal string. Defaults to \"0755\".",
+							Optional:    true,
+						},
+						"content_sha256": schema.StringAttribute{
+							Description: "SHA-256 checksum of the file contents, useful for wiring into downstream resource triggers without re-reading the file.",
+							Computed:    true,
 						},
 					},
 				},
@@ -65,6 +119,24 @@ func (r *fileDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, r
 			"add_newline_at_end": schema.BoolAttribute{
 				Optional: true,
 			},
+			"skip_unchanged": schema.BoolAttribute{
+				Description: "If true, skip writing a file whose on-disk contents already match the desired contents, instead of unconditionally overwriting it.",
+				Optional:    true,
+			},
+			"prune_roots": schema.ListAttribute{
+				Description: "Directories that this data source owns. After writing files, any regular file found under these roots that isn't listed in files (and doesn't match prune_globs) is deleted.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"prune_globs": schema.ListAttribute{
+				Description: "Doublestar-style glob patterns, matched against each file's path relative to its prune_roots entry, to exclude from pruning (e.g. \"**/.git/**\").",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"dry_run": schema.BoolAttribute{
+				Description: "If true, don't delete anything under prune_roots - report the files that would have been pruned as warnings instead.",
+				Optional:    true,
+			},
 		},
 	}
 }
@@ -85,25 +157,247 @@ func (r *fileDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 	}
 
 	for _, file := range plan.Files {
-		createOrUpdateSingleFile(file, osLineEnding, plan.AddNewlineAtEnd.ValueBool(), &resp.Diagnostics)
+		createOrUpdateSingleFile(file, osLineEnding, plan.AddNewlineAtEnd.ValueBool(), plan.SkipUnchanged.ValueBool(), &resp.Diagnostics)
 		// Unclear if this is the best way to do this - don't save the file contents in the state
 		file.FileContents = types.StringNull()
+		file.ContentBase64 = types.StringNull()
+	}
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
+	pruneUnmanaged(plan, &resp.Diagnostics)
+
 	resp.State.Set(ctx, plan)
 }
 
-func createOrUpdateSingleFile(file *fileModel, osLineEnding string, addNewlineAtEnd bool, diag *diag.Diagnostics) {
-	editedContents := file.FileContents.ValueString()
-	if addNewlineAtEnd && !strings.HasSuffix(editedContents, osLineEnding) {
-		editedContents = editedContents + osLineEnding
+// pruneUnmanaged deletes (or, with dry_run, reports) regular files found
+// under plan.PruneRoots that aren't one of plan.Files and don't match
+// plan.PruneGlobs. This turns the data source into a declarative sync over
+// a directory tree instead of just a batch writer.
+func pruneUnmanaged(plan fileDataSourceModel, diags *diag.Diagnostics) {
+	if len(plan.PruneRoots) == 0 {
+		return
 	}
-	fileBytes := []byte(editedContents)
 
-	// Just overwrite anything existing - likely to be faster than checking if it exists and matches the content
-	err := os.WriteFile(file.Filename.ValueString(), fileBytes, 0644)
+	managed := make(map[string]bool, len(plan.Files))
+	for _, file := range plan.Files {
+		abs, err := filepath.Abs(file.Filename.ValueString())
+		if err != nil {
+			diags.AddError("Failed to resolve managed file path.", err.Error())
+			return
+		}
+		managed[abs] = true
+	}
+
+	globs := make([]string, 0, len(plan.PruneGlobs))
+	for _, g := range plan.PruneGlobs {
+		globs = append(globs, g.ValueString())
+	}
+
+	for _, root := range plan.PruneRoots {
+		rootPath := root.ValueString()
+
+		err := filepath.WalkDir(rootPath, func(walkPath string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.Type().IsRegular() {
+				// Skips directories (so WalkDir still recurses into them)
+				// as well as symlinks, sockets, and other non-regular
+				// entries, which are never candidates for pruning.
+				return nil
+			}
+
+			abs, err := filepath.Abs(walkPath)
+			if err != nil {
+				return err
+			}
+			if managed[abs] {
+				return nil
+			}
+
+			rel, err := filepath.Rel(rootPath, walkPath)
+			if err != nil {
+				return err
+			}
+			for _, g := range globs {
+				matched, err := doublestar.Match(g, rel)
+				if err != nil {
+					return fmt.Errorf("invalid prune_globs pattern %q: %w", g, err)
+				}
+				if matched {
+					return nil
+				}
+			}
+
+			if plan.DryRun.ValueBool() {
+				diags.AddWarning(
+					"Would prune unmanaged file.",
+					fmt.Sprintf("%s is not listed in files and would be deleted under prune_roots %q (dry_run is enabled).", walkPath, rootPath),
+				)
+				return nil
+			}
+
+			return os.Remove(walkPath)
+		})
+		if err != nil {
+			diags.AddError("Failed to prune unmanaged files.", err.Error())
+			return
+		}
+	}
+}
+
+func createOrUpdateSingleFile(file *fileModel, osLineEnding string, addNewlineAtEnd bool, skipUnchanged bool, diag *diag.Diagnostics) {
+	src, err := openDesiredContents(file, osLineEnding, addNewlineAtEnd)
+	if err != nil {
+		diag.AddError("Failed to resolve file contents.", err.Error())
+		return
+	}
+	size, sum, err := hashReader(src)
+	src.Close()
 	if err != nil {
-		diag.AddError("Failed to write file.", err.Error())
+		diag.AddError("Failed to hash file contents.", err.Error())
 		return
 	}
+	file.ContentSha256 = types.StringValue(hex.EncodeToString(sum[:]))
+
+	writeNeeded := true
+	if skipUnchanged {
+		unchanged, err := fileMatchesHash(file.Filename.ValueString(), size, sum)
+		if err != nil {
+			diag.AddError("Failed to check existing file contents.", err.Error())
+			return
+		}
+		writeNeeded = !unchanged
+	}
+
+	filePerm, err := parseFileModeOrDefault(file.FilePermission, "0644")
+	if err != nil {
+		diag.AddError("Invalid file_permission.", err.Error())
+		return
+	}
+	dirPerm, err := parseFileModeOrDefault(file.DirectoryPermission, "0755")
+	if err != nil {
+		diag.AddError("Invalid directory_permission.", err.Error())
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(file.Filename.ValueString()), dirPerm); err != nil {
+		diag.AddError("Failed to create parent directory.", err.Error())
+		return
+	}
+
+	if writeNeeded {
+		dst, err := os.OpenFile(file.Filename.ValueString(), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, filePerm)
+		if err != nil {
+			diag.AddError("Failed to write file.", err.Error())
+			return
+		}
+
+		src, err = openDesiredContents(file, osLineEnding, addNewlineAtEnd)
+		if err != nil {
+			dst.Close()
+			diag.AddError("Failed to resolve file contents.", err.Error())
+			return
+		}
+
+		_, copyErr := io.Copy(dst, src)
+		src.Close()
+		dst.Close()
+		if copyErr != nil {
+			diag.AddError("Failed to write file.", copyErr.Error())
+			return
+		}
+	}
+
+	// os.OpenFile only applies filePerm when creating the file, so an
+	// existing file with a stale mode needs an explicit chmod to converge.
+	if err := os.Chmod(file.Filename.ValueString(), filePerm); err != nil {
+		diag.AddError("Failed to set file permissions.", err.Error())
+		return
+	}
+}
+
+// openDesiredContents returns a reader over what file's contents should be,
+// regardless of whether they come from an inline string, base64, or an
+// existing file on disk. Callers are responsible for closing it.
+func openDesiredContents(file *fileModel, osLineEnding string, addNewlineAtEnd bool) (io.ReadCloser, error) {
+	switch {
+	case !file.SourcePath.IsNull():
+		return os.Open(file.SourcePath.ValueString())
+	case !file.ContentBase64.IsNull():
+		decoded, err := base64.StdEncoding.DecodeString(file.ContentBase64.ValueString())
+		if err != nil {
+			return nil, err
+		}
+		return io.NopCloser(bytes.NewReader(decoded)), nil
+	default:
+		editedContents := file.FileContents.ValueString()
+		if addNewlineAtEnd && !strings.HasSuffix(editedContents, osLineEnding) {
+			editedContents = editedContents + osLineEnding
+		}
+		return io.NopCloser(strings.NewReader(editedContents)), nil
+	}
+}
+
+// parseFileModeOrDefault parses s as an octal permission string, falling
+// back to def when s is unset.
+func parseFileModeOrDefault(s types.String, def string) (os.FileMode, error) {
+	if s.IsNull() || s.ValueString() == "" {
+		return parseFileMode(def)
+	}
+	return parseFileMode(s.ValueString())
+}
+
+// hashReader streams r through sha256 in hashChunkSize chunks, returning
+// the total byte count and checksum without buffering the whole input.
+func hashReader(r io.Reader) (int64, [sha256.Size]byte, error) {
+	h := sha256.New()
+	buf := make([]byte, hashChunkSize)
+	var size int64
+	for {
+		n, readErr := r.Read(buf)
+		if n > 0 {
+			h.Write(buf[:n])
+			size += int64(n)
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return 0, [sha256.Size]byte{}, readErr
+		}
+	}
+
+	var sum [sha256.Size]byte
+	copy(sum[:], h.Sum(nil))
+	return size, sum, nil
+}
+
+// fileMatchesHash reports whether the file at path already has the given
+// size and SHA-256 checksum.
+func fileMatchesHash(filePath string, wantSize int64, wantSum [sha256.Size]byte) (bool, error) {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if info.Size() != wantSize {
+		return false, nil
+	}
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	_, gotSum, err := hashReader(f)
+	if err != nil {
+		return false, err
+	}
+	return gotSum == wantSum, nil
 }
@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestCreateOrUpdateSingleFileSkipUnchangedSkipsWrite(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "out.txt")
+	if err := os.WriteFile(target, []byte("same contents"), 0644); err != nil {
+		t.Fatalf("failed to seed file: %v", err)
+	}
+	before, err := os.Stat(target)
+	if err != nil {
+		t.Fatalf("failed to stat seed file: %v", err)
+	}
+
+	file := &fileModel{
+		Filename:     types.StringValue(target),
+		FileContents: types.StringValue("same contents"),
+	}
+
+	var diags diag.Diagnostics
+	createOrUpdateSingleFile(file, "\n", false, true, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags.Errors())
+	}
+
+	after, err := os.Stat(target)
+	if err != nil {
+		t.Fatalf("failed to stat file after write attempt: %v", err)
+	}
+	if !after.ModTime().Equal(before.ModTime()) {
+		t.Fatalf("file was rewritten even though contents matched: mtime changed from %v to %v", before.ModTime(), after.ModTime())
+	}
+}
+
+func TestCreateOrUpdateSingleFileSkipUnchangedWritesOnMismatch(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "out.txt")
+	if err := os.WriteFile(target, []byte("old contents"), 0644); err != nil {
+		t.Fatalf("failed to seed file: %v", err)
+	}
+
+	file := &fileModel{
+		Filename:     types.StringValue(target),
+		FileContents: types.StringValue("new contents"),
+	}
+
+	var diags diag.Diagnostics
+	createOrUpdateSingleFile(file, "\n", false, true, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags.Errors())
+	}
+
+	got, err := os.ReadFile(target)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	if string(got) != "new contents" {
+		t.Fatalf("got contents %q, want %q", got, "new contents")
+	}
+}
+
+func TestCreateOrUpdateSingleFileAppliesPermissions(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "nested", "out.txt")
+
+	file := &fileModel{
+		Filename:            types.StringValue(target),
+		FileContents:        types.StringValue("hello"),
+		FilePermission:      types.StringValue("0640"),
+		DirectoryPermission: types.StringValue("0750"),
+	}
+
+	var diags diag.Diagnostics
+	createOrUpdateSingleFile(file, "\n", false, false, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags.Errors())
+	}
+
+	info, err := os.Stat(target)
+	if err != nil {
+		t.Fatalf("failed to stat written file: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0640 {
+		t.Fatalf("got file mode %o, want %o", perm, 0640)
+	}
+}
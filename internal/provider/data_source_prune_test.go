@@ -0,0 +1,125 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestPruneUnmanagedDeletesUnmanagedRegularFiles(t *testing.T) {
+	root := t.TempDir()
+	managedPath := filepath.Join(root, "keep.txt")
+	unmanagedPath := filepath.Join(root, "stale.txt")
+	for _, p := range []string{managedPath, unmanagedPath} {
+		if err := os.WriteFile(p, []byte("x"), 0644); err != nil {
+			t.Fatalf("failed to seed %s: %v", p, err)
+		}
+	}
+
+	plan := fileDataSourceModel{
+		Files:      []*fileModel{{Filename: types.StringValue(managedPath)}},
+		PruneRoots: []types.String{types.StringValue(root)},
+	}
+
+	var diags diag.Diagnostics
+	pruneUnmanaged(plan, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags.Errors())
+	}
+
+	if _, err := os.Stat(managedPath); err != nil {
+		t.Fatalf("managed file was removed: %v", err)
+	}
+	if _, err := os.Stat(unmanagedPath); !os.IsNotExist(err) {
+		t.Fatalf("unmanaged file was not pruned: err=%v", err)
+	}
+}
+
+func TestPruneUnmanagedSkipsGlobbedFiles(t *testing.T) {
+	root := t.TempDir()
+	protectedPath := filepath.Join(root, ".git", "HEAD")
+	if err := os.MkdirAll(filepath.Dir(protectedPath), 0755); err != nil {
+		t.Fatalf("failed to create .git dir: %v", err)
+	}
+	if err := os.WriteFile(protectedPath, []byte("ref"), 0644); err != nil {
+		t.Fatalf("failed to seed protected file: %v", err)
+	}
+
+	plan := fileDataSourceModel{
+		PruneRoots: []types.String{types.StringValue(root)},
+		PruneGlobs: []types.String{types.StringValue(".git/**")},
+	}
+
+	var diags diag.Diagnostics
+	pruneUnmanaged(plan, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags.Errors())
+	}
+
+	if _, err := os.Stat(protectedPath); err != nil {
+		t.Fatalf("globbed file was pruned: %v", err)
+	}
+}
+
+func TestPruneUnmanagedSkipsNonRegularFiles(t *testing.T) {
+	root := t.TempDir()
+	realFile := filepath.Join(root, "real.txt")
+	if err := os.WriteFile(realFile, []byte("x"), 0644); err != nil {
+		t.Fatalf("failed to seed file: %v", err)
+	}
+	symlinkPath := filepath.Join(root, "link.txt")
+	if err := os.Symlink(realFile, symlinkPath); err != nil {
+		t.Fatalf("failed to create symlink: %v", err)
+	}
+
+	plan := fileDataSourceModel{
+		PruneRoots: []types.String{types.StringValue(root)},
+	}
+
+	var diags diag.Diagnostics
+	pruneUnmanaged(plan, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags.Errors())
+	}
+
+	if _, err := os.Lstat(symlinkPath); err != nil {
+		t.Fatalf("symlink was pruned: %v", err)
+	}
+	// realFile is unmanaged and isn't excluded by any glob, so it's the one
+	// entry in this tree that should actually be pruned.
+	if _, err := os.Stat(realFile); !os.IsNotExist(err) {
+		t.Fatalf("unmanaged regular file was not pruned: err=%v", err)
+	}
+}
+
+func TestPruneUnmanagedDryRunOnlyWarns(t *testing.T) {
+	root := t.TempDir()
+	unmanagedPath := filepath.Join(root, "stale.txt")
+	if err := os.WriteFile(unmanagedPath, []byte("x"), 0644); err != nil {
+		t.Fatalf("failed to seed file: %v", err)
+	}
+
+	plan := fileDataSourceModel{
+		PruneRoots: []types.String{types.StringValue(root)},
+		DryRun:     types.BoolValue(true),
+	}
+
+	var diags diag.Diagnostics
+	pruneUnmanaged(plan, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags.Errors())
+	}
+	if len(diags.Warnings()) == 0 {
+		t.Fatalf("expected a warning about the file that would be pruned")
+	}
+
+	if _, err := os.Stat(unmanagedPath); err != nil {
+		t.Fatalf("dry_run deleted the file: %v", err)
+	}
+}
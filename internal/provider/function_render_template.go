@@ -0,0 +1,185 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"gopkg.in/yaml.v3"
+)
+
+var _ function.Function = &renderTemplateFunction{}
+
+// renderTemplateFunction renders a Go text/template string against an
+// arbitrary-shaped object of variables, so callers can feed the result
+// straight into fastlocal_file's content attribute without a separate
+// templatefile() call and local_file resource.
+type renderTemplateFunction struct{}
+
+func NewRenderTemplateFunction() function.Function {
+	return &renderTemplateFunction{}
+}
+
+func (f *renderTemplateFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "render_template"
+}
+
+func (f *renderTemplateFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Renders a Go text/template string using a set of variables.",
+		Description: "Renders `template` using Go's text/template syntax against `vars`. The helpers toJson, toYaml, indent, quote, and env are available inside the template.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "template",
+				Description: "Go text/template source to render.",
+			},
+			function.DynamicParameter{
+				Name:        "vars",
+				Description: "Variables made available to the template as the root `.` value. Can be any object shape.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *renderTemplateFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var templateSrc string
+	var vars types.Dynamic
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &templateSrc, &vars))
+	if resp.Error != nil {
+		return
+	}
+
+	data, err := dynamicToGo(ctx, vars)
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(1, fmt.Sprintf("failed to interpret vars: %s", err)))
+		return
+	}
+
+	tmpl, err := template.New("render_template").Funcs(templateFuncs()).Parse(templateSrc)
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(0, fmt.Sprintf("failed to parse template: %s", err)))
+		return
+	}
+
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewFuncError(fmt.Sprintf("failed to render template: %s", err)))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, rendered.String()))
+}
+
+// templateFuncs returns the curated set of helpers available to render_template
+// templates, covering the structured-data and formatting needs that would
+// otherwise require Terraform's own jsonencode/yamlencode/indent functions.
+func templateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"toJson": func(v any) (string, error) {
+			b, err := json.Marshal(v)
+			if err != nil {
+				return "", err
+			}
+			return string(b), nil
+		},
+		"toYaml": func(v any) (string, error) {
+			b, err := yaml.Marshal(v)
+			if err != nil {
+				return "", err
+			}
+			return strings.TrimSuffix(string(b), "\n"), nil
+		},
+		"indent": func(spaces int, s string) string {
+			pad := strings.Repeat(" ", spaces)
+			return pad + strings.ReplaceAll(s, "\n", "\n"+pad)
+		},
+		"quote": func(s string) string {
+			return fmt.Sprintf("%q", s)
+		},
+		"env": func(key string) string {
+			return os.Getenv(key)
+		},
+	}
+}
+
+// dynamicToGo converts a types.Dynamic function argument into plain Go
+// values (map[string]any, []any, string, float64, bool, nil) so it can be
+// used as the data argument to text/template.Execute.
+func dynamicToGo(ctx context.Context, v types.Dynamic) (any, error) {
+	if v.IsNull() || v.IsUnknown() {
+		return nil, nil
+	}
+
+	tfVal, err := v.UnderlyingValue().ToTerraformValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return tftypesValueToGo(tfVal)
+}
+
+func tftypesValueToGo(v tftypes.Value) (any, error) {
+	if !v.IsKnown() || v.IsNull() {
+		return nil, nil
+	}
+
+	switch {
+	case v.Type().Is(tftypes.String):
+		var s string
+		err := v.As(&s)
+		return s, err
+	case v.Type().Is(tftypes.Number):
+		var f big.Float
+		if err := v.As(&f); err != nil {
+			return nil, err
+		}
+		result, _ := f.Float64()
+		return result, nil
+	case v.Type().Is(tftypes.Bool):
+		var b bool
+		err := v.As(&b)
+		return b, err
+	case v.Type().Is(tftypes.List{}), v.Type().Is(tftypes.Set{}), v.Type().Is(tftypes.Tuple{}):
+		var elems []tftypes.Value
+		if err := v.As(&elems); err != nil {
+			return nil, err
+		}
+		result := make([]any, len(elems))
+		for i, elem := range elems {
+			converted, err := tftypesValueToGo(elem)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = converted
+		}
+		return result, nil
+	case v.Type().Is(tftypes.Object{}), v.Type().Is(tftypes.Map{}):
+		elems := map[string]tftypes.Value{}
+		if err := v.As(&elems); err != nil {
+			return nil, err
+		}
+		result := make(map[string]any, len(elems))
+		for k, elem := range elems {
+			converted, err := tftypesValueToGo(elem)
+			if err != nil {
+				return nil, err
+			}
+			result[k] = converted
+		}
+		return result, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %s in render_template vars", v.Type())
+	}
+}
@@ -46,7 +46,9 @@ func (p *FastLocalProvider) Configure(ctx context.Context, req provider.Configur
 }
 
 func (p *FastLocalProvider) Resources(ctx context.Context) []func() resource.Resource {
-	return []func() resource.Resource{}
+	return []func() resource.Resource{
+		NewFileResource,
+	}
 }
 
 func (p *FastLocalProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
@@ -56,7 +58,9 @@ func (p *FastLocalProvider) DataSources(ctx context.Context) []func() datasource
 }
 
 func (p *FastLocalProvider) Functions(ctx context.Context) []func() function.Function {
-	return []func() function.Function{}
+	return []func() function.Function{
+		NewRenderTemplateFunction,
+	}
 }
 
 func New(version string) func() provider.Provider {
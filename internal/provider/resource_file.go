@@ -0,0 +1,277 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                     = &fileResource{}
+	_ resource.ResourceWithConfigValidators = &fileResource{}
+	_ resource.ResourceWithImportState      = &fileResource{}
+)
+
+// fileResource manages the full lifecycle of a single local file. Unlike
+// fileDataSource, which is a fire-and-forget batch writer, this tracks
+// drift on Read and cleans up the file on Delete.
+type fileResource struct {
+}
+
+type fileResourceModel struct {
+	Filename            types.String `tfsdk:"filename"`
+	Content             types.String `tfsdk:"content"`
+	ContentBase64       types.String `tfsdk:"content_base64"`
+	ContentSha256       types.String `tfsdk:"content_sha256"`
+	FilePermission      types.String `tfsdk:"file_permission"`
+	DirectoryPermission types.String `tfsdk:"directory_permission"`
+	SkipUnchanged       types.Bool   `tfsdk:"skip_unchanged"`
+}
+
+func NewFileResource() resource.Resource {
+	return &fileResource{}
+}
+
+func (r *fileResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_file"
+}
+
+func (r *fileResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a single local file, including its permissions. Use this instead of the `fastlocal_file` data source when Terraform needs to track drift and clean up the file on destroy.",
+		Attributes: map[string]schema.Attribute{
+			"filename": schema.StringAttribute{
+				Description: "Path to the file to manage. Also serves as the resource's ID.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"content": schema.StringAttribute{
+				Description: "Contents to write to the file. Conflicts with `content_base64`.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"content_base64": schema.StringAttribute{
+				Description: "Base64-encoded contents to write to the file, for binary data. Conflicts with `content`.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"content_sha256": schema.StringAttribute{
+				Description: "SHA-256 checksum of the file contents. Used to detect drift on refresh.",
+				Computed:    true,
+			},
+			"file_permission": schema.StringAttribute{
+				Description: "Permissions to set for the created file, expressed as a 4-digit octal string such as \"0644\".",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("0644"),
+			},
+			"directory_permission": schema.StringAttribute{
+				Description: "Permissions to set for any parent directories created for the file, expressed as a 4-digit octal string such as \"0755\".",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("0755"),
+			},
+			"skip_unchanged": schema.BoolAttribute{
+				Description: "If true, skip writing the file when its on-disk contents already match the desired contents, instead of unconditionally overwriting it.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *fileResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.Conflicting(
+			path.MatchRoot("content"),
+			path.MatchRoot("content_base64"),
+		),
+	}
+}
+
+func (r *fileResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("filename"), req, resp)
+}
+
+func (r *fileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan fileResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	writeFileResource(&plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *fileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state fileResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	info, err := os.Stat(state.Filename.ValueString())
+	if os.IsNotExist(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to stat file.", err.Error())
+		return
+	}
+
+	if err := refreshFileResourceState(&state, info); err != nil {
+		resp.Diagnostics.AddError("Failed to read file.", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// refreshFileResourceState reads the file at state.Filename and updates
+// state to reflect what's actually on disk: content_sha256 and
+// file_permission always, and content/content_base64 too if the on-disk
+// hash no longer matches the last-applied one, so drift from an external
+// edit shows up as a plan diff instead of being silently absorbed.
+func refreshFileResourceState(state *fileResourceModel, info os.FileInfo) error {
+	onDiskBytes, err := os.ReadFile(state.Filename.ValueString())
+	if err != nil {
+		return err
+	}
+
+	sum := sha256.Sum256(onDiskBytes)
+	onDiskSha256 := hex.EncodeToString(sum[:])
+
+	if onDiskSha256 != state.ContentSha256.ValueString() {
+		if !state.ContentBase64.IsNull() {
+			state.ContentBase64 = types.StringValue(base64.StdEncoding.EncodeToString(onDiskBytes))
+		} else {
+			state.Content = types.StringValue(string(onDiskBytes))
+		}
+		state.ContentSha256 = types.StringValue(onDiskSha256)
+	}
+
+	state.FilePermission = types.StringValue(fmt.Sprintf("%04o", info.Mode().Perm()))
+	return nil
+}
+
+func (r *fileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan fileResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	writeFileResource(&plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *fileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state fileResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := os.Remove(state.Filename.ValueString()); err != nil && !os.IsNotExist(err) {
+		resp.Diagnostics.AddError("Failed to delete file.", err.Error())
+	}
+}
+
+// writeFileResource resolves the desired content, creates any missing
+// parent directories, writes the file to disk (unless skip_unchanged is set
+// and the on-disk contents already match), applies file_permission, and
+// populates the computed content_sha256 attribute on plan.
+func writeFileResource(plan *fileResourceModel, diags *diag.Diagnostics) {
+	var fileBytes []byte
+	if !plan.ContentBase64.IsNull() {
+		decoded, err := base64.StdEncoding.DecodeString(plan.ContentBase64.ValueString())
+		if err != nil {
+			diags.AddError("Failed to decode content_base64.", err.Error())
+			return
+		}
+		fileBytes = decoded
+	} else {
+		fileBytes = []byte(plan.Content.ValueString())
+	}
+
+	sum := sha256.Sum256(fileBytes)
+	plan.ContentSha256 = types.StringValue(hex.EncodeToString(sum[:]))
+
+	writeNeeded := true
+	if plan.SkipUnchanged.ValueBool() {
+		unchanged, err := fileMatchesHash(plan.Filename.ValueString(), int64(len(fileBytes)), sum)
+		if err != nil {
+			diags.AddError("Failed to check existing file contents.", err.Error())
+			return
+		}
+		writeNeeded = !unchanged
+	}
+
+	dirPerm, err := parseFileMode(plan.DirectoryPermission.ValueString())
+	if err != nil {
+		diags.AddError("Invalid directory_permission.", err.Error())
+		return
+	}
+	filePerm, err := parseFileMode(plan.FilePermission.ValueString())
+	if err != nil {
+		diags.AddError("Invalid file_permission.", err.Error())
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(plan.Filename.ValueString()), dirPerm); err != nil {
+		diags.AddError("Failed to create parent directory.", err.Error())
+		return
+	}
+
+	if writeNeeded {
+		if err := os.WriteFile(plan.Filename.ValueString(), fileBytes, filePerm); err != nil {
+			diags.AddError("Failed to write file.", err.Error())
+			return
+		}
+	}
+
+	// os.WriteFile only applies filePerm when creating the file, so an
+	// existing file with a stale mode needs an explicit chmod to converge.
+	if err := os.Chmod(plan.Filename.ValueString(), filePerm); err != nil {
+		diags.AddError("Failed to set file permissions.", err.Error())
+		return
+	}
+}
+
+// parseFileMode parses a permission string such as "0644" into an os.FileMode.
+func parseFileMode(s string) (os.FileMode, error) {
+	perm, err := strconv.ParseUint(s, 8, 32)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a valid octal permission string: %w", s, err)
+	}
+	return os.FileMode(perm), nil
+}
@@ -0,0 +1,133 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestWriteFileResourceCreatesFileWithPermissions(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "nested", "out.txt")
+
+	plan := &fileResourceModel{
+		Filename:            types.StringValue(target),
+		Content:             types.StringValue("hello"),
+		ContentBase64:       types.StringNull(),
+		FilePermission:      types.StringValue("0640"),
+		DirectoryPermission: types.StringValue("0750"),
+	}
+
+	var diags diag.Diagnostics
+	writeFileResource(plan, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags.Errors())
+	}
+
+	got, err := os.ReadFile(target)
+	if err != nil {
+		t.Fatalf("failed to read written file: %v", err)
+	}
+	if string(got) != "hello" {
+		t.Fatalf("got contents %q, want %q", got, "hello")
+	}
+
+	info, err := os.Stat(target)
+	if err != nil {
+		t.Fatalf("failed to stat written file: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0640 {
+		t.Fatalf("got file mode %o, want %o", perm, 0640)
+	}
+}
+
+func TestWriteFileResourceSkipUnchangedStillChmods(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "out.txt")
+	if err := os.WriteFile(target, []byte("hello"), 0644); err != nil {
+		t.Fatalf("failed to seed file: %v", err)
+	}
+
+	plan := &fileResourceModel{
+		Filename:            types.StringValue(target),
+		Content:             types.StringValue("hello"),
+		FilePermission:      types.StringValue("0600"),
+		DirectoryPermission: types.StringValue("0750"),
+		SkipUnchanged:       types.BoolValue(true),
+	}
+
+	var diags diag.Diagnostics
+	writeFileResource(plan, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags.Errors())
+	}
+
+	info, err := os.Stat(target)
+	if err != nil {
+		t.Fatalf("failed to stat file: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Fatalf("got file mode %o, want %o; skip_unchanged must not skip the chmod", perm, 0600)
+	}
+}
+
+func TestRefreshFileResourceStateSurfacesDrift(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "out.txt")
+	if err := os.WriteFile(target, []byte("changed on disk"), 0644); err != nil {
+		t.Fatalf("failed to seed file: %v", err)
+	}
+
+	state := &fileResourceModel{
+		Filename:      types.StringValue(target),
+		Content:       types.StringValue("original"),
+		ContentSha256: types.StringValue("does-not-match-anything"),
+	}
+
+	info, err := os.Stat(target)
+	if err != nil {
+		t.Fatalf("failed to stat file: %v", err)
+	}
+	if err := refreshFileResourceState(state, info); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := state.Content.ValueString(); got != "changed on disk" {
+		t.Fatalf("got content %q, want the on-disk value to surface as drift", got)
+	}
+}
+
+func TestRefreshFileResourceStateNoDriftLeavesContentAlone(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "out.txt")
+	if err := os.WriteFile(target, []byte("stable"), 0644); err != nil {
+		t.Fatalf("failed to seed file: %v", err)
+	}
+
+	sum := sha256.Sum256([]byte("stable"))
+	state := &fileResourceModel{
+		Filename:      types.StringValue(target),
+		Content:       types.StringValue("stable"),
+		ContentSha256: types.StringValue(hex.EncodeToString(sum[:])),
+	}
+
+	info, err := os.Stat(target)
+	if err != nil {
+		t.Fatalf("failed to stat file: %v", err)
+	}
+	if err := refreshFileResourceState(state, info); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := state.Content.ValueString(); got != "stable" {
+		t.Fatalf("got content %q, want unchanged value preserved when there's no drift", got)
+	}
+}